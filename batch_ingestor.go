@@ -0,0 +1,337 @@
+package langsmithgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchIngestorOptions configures when a BatchIngestor flushes its queue.
+// A flush is triggered by whichever of these trips first.
+type BatchIngestorOptions struct {
+	MaxBatchSize  int           // max number of runs per flush
+	MaxBatchBytes int           // max serialized size (bytes) per flush
+	MaxWait       time.Duration // max time a run waits before being flushed
+}
+
+// BatchIngestorStats exposes a point-in-time snapshot of ingestor activity.
+type BatchIngestorStats struct {
+	Enqueued int64
+	Flushed  int64
+	Dropped  int64
+	Retried  int64
+}
+
+// pendingRun tracks a queued PostPayload together with any PatchPayload that
+// has arrived for the same run ID before the post was flushed, so the patch
+// can be merged into the post instead of sent separately.
+type pendingRun struct {
+	runID string
+	post  *PostPayload
+	patch *PatchPayload
+}
+
+// PatchSubmission pairs a PatchPayload with the run ID it applies to, since
+// PatchPayload itself carries no run identifier. Pass one to Enqueue to
+// submit a patch for batching.
+type PatchSubmission struct {
+	RunID string
+	Patch *PatchPayload
+}
+
+// BatchIngestor coalesces PostPayload/PatchPayload submissions for a Client
+// and periodically flushes them as a single multipart upload to
+// /runs/multipart, instead of issuing one HTTP request per run. It is safe
+// for concurrent use.
+type BatchIngestor struct {
+	client *Client
+	opts   BatchIngestorOptions
+
+	mu      sync.Mutex
+	pending map[string]*pendingRun
+	order   []string // preserves enqueue order for deterministic flushes
+	bytes   int
+
+	flushCh chan struct{}
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+
+	enqueued int64
+	flushed  int64
+	dropped  int64
+	retried  int64
+}
+
+// NewBatchIngestor creates a BatchIngestor bound to client and starts its
+// background flusher goroutine. Call Close to drain the queue and stop it.
+func NewBatchIngestor(client *Client, opts BatchIngestorOptions) *BatchIngestor {
+	if opts.MaxBatchSize <= 0 {
+		opts.MaxBatchSize = 100
+	}
+	if opts.MaxBatchBytes <= 0 {
+		opts.MaxBatchBytes = 5 << 20 // 5MiB
+	}
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = time.Second
+	}
+
+	b := &BatchIngestor{
+		client:  client,
+		opts:    opts,
+		pending: make(map[string]*pendingRun),
+		flushCh: make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.loop()
+
+	return b
+}
+
+// Enqueue submits payload for batching without blocking on network I/O.
+// payload must be a *PostPayload or a *PatchSubmission. A patch for a run ID
+// still sitting in the queue as a post is merged into that post's outputs,
+// end time and error instead of being sent as a separate request.
+func (b *BatchIngestor) Enqueue(payload any) error {
+	var runID string
+	var data []byte
+	var err error
+
+	b.mu.Lock()
+	switch p := payload.(type) {
+	case *PostPayload:
+		runID = p.ID
+		if pr, ok := b.pending[runID]; ok {
+			pr.post = p
+		} else {
+			b.pending[runID] = &pendingRun{runID: runID, post: p}
+			b.order = append(b.order, runID)
+		}
+		data, err = json.Marshal(p)
+	case *PatchSubmission:
+		runID = p.RunID
+		if pr, ok := b.pending[runID]; ok {
+			pr.patch = p.Patch
+		} else {
+			b.pending[runID] = &pendingRun{runID: runID, patch: p.Patch}
+			b.order = append(b.order, runID)
+		}
+		data, err = json.Marshal(p.Patch)
+	default:
+		b.mu.Unlock()
+		return fmt.Errorf("langsmithgo: BatchIngestor.Enqueue: unsupported payload type %T", payload)
+	}
+	if err == nil {
+		b.bytes += len(data)
+	}
+	tripSize := len(b.order) >= b.opts.MaxBatchSize || b.bytes >= b.opts.MaxBatchBytes
+	b.mu.Unlock()
+
+	atomic.AddInt64(&b.enqueued, 1)
+
+	if tripSize {
+		b.requestFlush()
+	}
+	return nil
+}
+
+func (b *BatchIngestor) requestFlush() {
+	select {
+	case b.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// Stats returns a snapshot of the ingestor's counters.
+func (b *BatchIngestor) Stats() BatchIngestorStats {
+	return BatchIngestorStats{
+		Enqueued: atomic.LoadInt64(&b.enqueued),
+		Flushed:  atomic.LoadInt64(&b.flushed),
+		Dropped:  atomic.LoadInt64(&b.dropped),
+		Retried:  atomic.LoadInt64(&b.retried),
+	}
+}
+
+func (b *BatchIngestor) loop() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.opts.MaxWait)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.flush(context.Background())
+		case <-b.flushCh:
+			b.flush(context.Background())
+		case <-b.closeCh:
+			b.flush(context.Background())
+			return
+		}
+	}
+}
+
+func (b *BatchIngestor) drain() []*pendingRun {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.order) == 0 {
+		return nil
+	}
+
+	runs := make([]*pendingRun, 0, len(b.order))
+	for _, id := range b.order {
+		if pr, ok := b.pending[id]; ok {
+			runs = append(runs, pr)
+		}
+	}
+	b.pending = make(map[string]*pendingRun)
+	b.order = nil
+	b.bytes = 0
+	return runs
+}
+
+func (b *BatchIngestor) flush(ctx context.Context) {
+	runs := b.drain()
+	if len(runs) == 0 {
+		return
+	}
+
+	body, contentType, err := buildMultipartRuns(runs)
+	if err != nil {
+		atomic.AddInt64(&b.dropped, int64(len(runs)))
+		return
+	}
+
+	const maxAttempts = 5
+	backoff := 200 * time.Millisecond
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.client.baseUrl+"/runs/multipart", bytes.NewReader(body))
+		if err != nil {
+			atomic.AddInt64(&b.dropped, int64(len(runs)))
+			return
+		}
+		req.Header.Set("x-api-key", b.client.APIKey)
+		req.Header.Set("Content-Type", contentType)
+
+		resp, err := b.client.client().Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 300 {
+				atomic.AddInt64(&b.flushed, int64(len(runs)))
+				return
+			}
+			if (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500) && attempt < maxAttempts-1 {
+				atomic.AddInt64(&b.retried, 1)
+				time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff))))
+				backoff *= 2
+				continue
+			}
+		} else if attempt < maxAttempts-1 {
+			atomic.AddInt64(&b.retried, 1)
+			time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff))))
+			backoff *= 2
+			continue
+		}
+
+		atomic.AddInt64(&b.dropped, int64(len(runs)))
+		return
+	}
+}
+
+// mergePatchIntoPost copies every field PatchPayload carries onto the
+// corresponding PostPayload field, so a run that picked up a patch before
+// its post was flushed goes out as one complete record instead of a post
+// with no end time, events or extras.
+func mergePatchIntoPost(post *PostPayload, patch *PatchPayload) {
+	post.Outputs = patch.Outputs
+	post.EndTime = patch.EndTime
+	post.Events = patch.Events
+	post.Error = patch.Error
+	if patch.SessionID != "" {
+		post.SessionID = patch.SessionID
+	}
+
+	for k, v := range patch.Extras {
+		if post.Extras == nil {
+			post.Extras = make(map[string]interface{}, len(patch.Extras))
+		}
+		post.Extras[k] = v
+	}
+}
+
+// buildMultipartRuns renders queued runs as the multipart/form-data body
+// LangSmith's /runs/multipart endpoint expects: one JSON part per run named
+// after its ID, with pending patches already merged into the post.
+func buildMultipartRuns(runs []*pendingRun) ([]byte, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	for _, pr := range runs {
+		if pr.post == nil {
+			// A patch with no matching post in this flush window is sent on
+			// its own part so the server can apply it to a run flushed earlier.
+			part, err := mw.CreateFormField(fmt.Sprintf("patch.%s", pr.runID))
+			if err != nil {
+				return nil, "", err
+			}
+			data, err := json.Marshal(pr.patch)
+			if err != nil {
+				return nil, "", err
+			}
+			if _, err := part.Write(data); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+
+		if pr.patch != nil {
+			mergePatchIntoPost(pr.post, pr.patch)
+		}
+
+		part, err := mw.CreateFormField(fmt.Sprintf("post.%s", pr.post.ID))
+		if err != nil {
+			return nil, "", err
+		}
+		data, err := json.Marshal(pr.post)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), mw.FormDataContentType(), nil
+}
+
+// Close stops the background flusher after draining any queued runs, or
+// returns ctx.Err() if ctx is cancelled first.
+func (b *BatchIngestor) Close(ctx context.Context) error {
+	close(b.closeCh)
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,97 @@
+package langsmithgo
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMultipartRunsMergesPatchIntoPost(t *testing.T) {
+	endTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	runs := []*pendingRun{
+		{
+			runID: "run-1",
+			post: &PostPayload{
+				ID:   "run-1",
+				Name: "chain-run",
+			},
+			patch: &PatchPayload{
+				Outputs: map[string]interface{}{"answer": "42"},
+				EndTime: endTime,
+				Error:   "boom",
+			},
+		},
+	}
+
+	body, contentType, err := buildMultipartRuns(runs)
+	if err != nil {
+		t.Fatalf("buildMultipartRuns: %v", err)
+	}
+
+	parts := parseMultipartFields(t, body, contentType)
+
+	raw, ok := parts["post.run-1"]
+	if !ok {
+		t.Fatalf("expected a post.run-1 part, got parts %v", mapKeys(parts))
+	}
+
+	var post PostPayload
+	if err := json.Unmarshal([]byte(raw), &post); err != nil {
+		t.Fatalf("unmarshal merged post: %v", err)
+	}
+
+	if post.Name != "chain-run" {
+		t.Errorf("Name = %q, want %q (post fields must survive the merge)", post.Name, "chain-run")
+	}
+	if post.Outputs["answer"] != "42" {
+		t.Errorf("Outputs = %v, want patch outputs to be merged in", post.Outputs)
+	}
+	if !post.EndTime.Equal(endTime) {
+		t.Errorf("EndTime = %v, want %v", post.EndTime, endTime)
+	}
+	if post.Error != "boom" {
+		t.Errorf("Error = %q, want %q", post.Error, "boom")
+	}
+}
+
+func parseMultipartFields(t *testing.T, body []byte, contentType string) map[string]string {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("parse content type: %v", err)
+	}
+
+	mr := multipart.NewReader(strings.NewReader(string(body)), params["boundary"])
+	fields := map[string]string{}
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		fields[part.FormName()] = readAll(t, part)
+	}
+	return fields
+}
+
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read part: %v", err)
+	}
+	return string(data)
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,187 @@
+package langsmithgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// NewClient creates a new LangSmith tracing client scoped to projectName.
+// The client requires an API key to authenticate requests.
+// You can get an API key by signing up for a LangSmith account at https://smith.langchain.com
+// The API key is read from the environment variable LANGSMITH_API_KEY
+//
+// Pass ClientOptions to customize the transport, e.g. for self-hosted
+// LangSmith deployments or corporate proxies; see WithTransport, WithBaseURL,
+// WithTLSConfig, WithProxy and WithTimeout.
+func NewClient(projectName string, opts ...ClientOption) (*Client, error) {
+	if os.Getenv("LANGSMITH_API_KEY") == "" {
+		return nil, errors.New("langsmith api key is required")
+	}
+
+	cfg := ClientConfig{BaseURL: os.Getenv("LANGSMITH_URL")}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = BASE_URL
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	httpClient, err := cfg.buildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		baseClient: baseClient{
+			APIKey:     os.Getenv("LANGSMITH_API_KEY"),
+			baseUrl:    cfg.BaseURL,
+			httpClient: httpClient,
+		},
+		projectName: projectName,
+	}, nil
+}
+
+// PostRun creates a new run in LangSmith.
+func (c *Client) PostRun(payload *PostPayload) error {
+	return c.PostRunWithContext(context.Background(), payload)
+}
+
+// PostRunWithContext is PostRun, but the request is bound to ctx so callers
+// embedding the client inside a server handler can cancel or deadline it
+// alongside the rest of the handler's work.
+func (c *Client) PostRunWithContext(ctx context.Context, payload *PostPayload) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return c.doWithContext(ctx, http.MethodPost, c.baseUrl+"/runs", jsonData)
+}
+
+// PatchRun updates an existing run identified by runID.
+func (c *Client) PatchRun(runID string, payload *PatchPayload) error {
+	return c.PatchRunWithContext(context.Background(), runID, payload)
+}
+
+// PatchRunWithContext is PatchRun, but the request is bound to ctx.
+func (c *Client) PatchRunWithContext(ctx context.Context, runID string, payload *PatchPayload) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return c.doWithContext(ctx, http.MethodPatch, fmt.Sprintf("%s/runs/%s", c.baseUrl, runID), jsonData)
+}
+
+// doWithContext issues a JSON request against the LangSmith API bound to ctx.
+func (b *baseClient) doWithContext(ctx context.Context, method, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", b.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return handleResponse(resp)
+}
+
+// postForm issues a POST with an arbitrary body and content type, e.g. a
+// multipart/form-data upload, bound to ctx.
+func (b *baseClient) postForm(ctx context.Context, url string, body io.Reader, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", b.APIKey)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return handleResponse(resp)
+}
+
+// client returns the configured transport, falling back to
+// http.DefaultClient when no httpClient was set (e.g. a DatasetClient from
+// NewDatasetClient).
+func (b *baseClient) client() *http.Client {
+	if b.httpClient != nil {
+		return b.httpClient
+	}
+	return http.DefaultClient
+}
+
+// deadlineTimer lets a caller bound the read and write phases of a single
+// in-flight call independently of ctx's own deadline, mirroring the
+// SetReadDeadline/SetWriteDeadline pattern net.Conn exposes. Arming either
+// deadline cancels the context once it elapses, which aborts the blocking
+// HTTP round trip started with it.
+type deadlineTimer struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	timer  *time.Timer
+}
+
+// newDeadlineTimer derives a cancellable context from ctx and returns it
+// alongside a deadlineTimer that can abort that context early.
+func newDeadlineTimer(ctx context.Context) (context.Context, *deadlineTimer) {
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, &deadlineTimer{cancel: cancel}
+}
+
+// SetReadDeadline arms the timer so the context is cancelled at t, aborting
+// a blocking read (e.g. waiting on the response body) past that point.
+func (d *deadlineTimer) SetReadDeadline(t time.Time) error {
+	return d.arm(t)
+}
+
+// SetWriteDeadline arms the timer so the context is cancelled at t, aborting
+// a blocking write (e.g. streaming the request body) past that point.
+func (d *deadlineTimer) SetWriteDeadline(t time.Time) error {
+	return d.arm(t)
+}
+
+func (d *deadlineTimer) arm(t time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	if t.IsZero() {
+		return nil
+	}
+
+	d.timer = time.AfterFunc(time.Until(t), d.cancel)
+	return nil
+}
+
+// Stop releases the timer without cancelling the context, for use once a
+// call completes successfully within its deadline.
+func (d *deadlineTimer) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
@@ -0,0 +1,157 @@
+package langsmithgo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// ClientConfig controls the transport NewClient uses to reach the LangSmith
+// API. It is built up by applying ClientOptions and is only used while
+// constructing the client (its base URL and *http.Client); it is not
+// retained afterwards.
+type ClientConfig struct {
+	// HTTPClient, if set, is used as-is and all other transport fields
+	// (TLS, Proxy, Timeout) are ignored.
+	HTTPClient *http.Client
+
+	// BaseURL overrides the LangSmith API base URL, for self-hosted
+	// deployments. Defaults to $LANGSMITH_URL, then BASE_URL.
+	BaseURL string
+
+	// CAFile, CertFile and KeyFile configure TLS for self-hosted
+	// deployments running behind a private certificate authority or
+	// requiring mutual TLS.
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	SkipVerify bool
+
+	// ProxyURL routes requests through an HTTP proxy, e.g.
+	// "http://proxy.corp.example.com:8080".
+	ProxyURL string
+
+	// Timeout bounds each request's total round trip. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// ClientOption mutates a ClientConfig; apply one or more via NewClient.
+type ClientOption func(*ClientConfig)
+
+// WithTransport uses httpClient as-is instead of building one from the
+// other ClientOptions.
+func WithTransport(httpClient *http.Client) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.HTTPClient = httpClient
+	}
+}
+
+// WithBaseURL overrides the LangSmith API base URL, e.g. for a self-hosted
+// deployment.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.BaseURL = baseURL
+	}
+}
+
+// WithTLSConfig configures a private CA and/or client certificate for
+// self-hosted deployments. skipVerify disables certificate verification
+// entirely and should only be used against trusted hosts in development.
+func WithTLSConfig(caFile, certFile, keyFile string, skipVerify bool) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.CAFile = caFile
+		cfg.CertFile = certFile
+		cfg.KeyFile = keyFile
+		cfg.SkipVerify = skipVerify
+	}
+}
+
+// WithProxy routes requests through the given HTTP proxy URL.
+func WithProxy(proxyURL string) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.ProxyURL = proxyURL
+	}
+}
+
+// WithTimeout bounds each request's total round trip.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *ClientConfig) {
+		cfg.Timeout = timeout
+	}
+}
+
+// ErrInvalidConfig reports a ClientConfig that cannot be used to build a
+// transport, e.g. an unparsable proxy URL or a certificate that can't be
+// read.
+type ErrInvalidConfig struct {
+	Reason string
+}
+
+func (e *ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("langsmithgo: invalid client config: %s", e.Reason)
+}
+
+func (cfg *ClientConfig) validate() error {
+	if cfg.HTTPClient != nil {
+		return nil
+	}
+	if cfg.ProxyURL != "" {
+		if _, err := url.Parse(cfg.ProxyURL); err != nil {
+			return &ErrInvalidConfig{Reason: fmt.Sprintf("proxy url: %s", err)}
+		}
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return &ErrInvalidConfig{Reason: "CertFile and KeyFile must be set together"}
+	}
+	return nil
+}
+
+// buildHTTPClient constructs the *http.Client described by cfg. cfg must
+// already be valid (see validate).
+func (cfg *ClientConfig) buildHTTPClient() (*http.Client, error) {
+	if cfg.HTTPClient != nil {
+		return cfg.HTTPClient, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.CAFile != "" || cfg.CertFile != "" || cfg.SkipVerify {
+		tlsConfig := &tls.Config{InsecureSkipVerify: cfg.SkipVerify}
+
+		if cfg.CAFile != "" {
+			pem, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, &ErrInvalidConfig{Reason: fmt.Sprintf("reading CAFile: %s", err)}
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, &ErrInvalidConfig{Reason: "CAFile contains no usable certificates"}
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if cfg.CertFile != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, &ErrInvalidConfig{Reason: fmt.Sprintf("loading client certificate: %s", err)}
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, &ErrInvalidConfig{Reason: fmt.Sprintf("proxy url: %s", err)}
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{Transport: transport, Timeout: cfg.Timeout}, nil
+}
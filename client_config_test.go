@@ -0,0 +1,150 @@
+package langsmithgo
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClientConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ClientConfig
+		wantErr bool
+	}{
+		{
+			name: "zero value is valid",
+			cfg:  ClientConfig{},
+		},
+		{
+			name: "HTTPClient set skips every other check",
+			cfg:  ClientConfig{HTTPClient: &http.Client{}, ProxyURL: "://not-a-url"},
+		},
+		{
+			name:    "bad proxy url",
+			cfg:     ClientConfig{ProxyURL: "://not-a-url"},
+			wantErr: true,
+		},
+		{
+			name: "valid proxy url",
+			cfg:  ClientConfig{ProxyURL: "http://proxy.example.com:8080"},
+		},
+		{
+			name:    "cert without key",
+			cfg:     ClientConfig{CertFile: "cert.pem"},
+			wantErr: true,
+		},
+		{
+			name:    "key without cert",
+			cfg:     ClientConfig{KeyFile: "key.pem"},
+			wantErr: true,
+		},
+		{
+			name: "cert and key together",
+			cfg:  ClientConfig{CertFile: "cert.pem", KeyFile: "key.pem"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*ErrInvalidConfig); !ok {
+					t.Errorf("error type = %T, want *ErrInvalidConfig", err)
+				}
+			}
+		})
+	}
+}
+
+func TestClientConfigBuildHTTPClient(t *testing.T) {
+	t.Run("HTTPClient is used as-is", func(t *testing.T) {
+		want := &http.Client{}
+		cfg := ClientConfig{HTTPClient: want}
+		got, err := cfg.buildHTTPClient()
+		if err != nil {
+			t.Fatalf("buildHTTPClient: %v", err)
+		}
+		if got != want {
+			t.Errorf("buildHTTPClient() = %p, want the same *http.Client %p passed in", got, want)
+		}
+	})
+
+	t.Run("missing CA file errors", func(t *testing.T) {
+		cfg := ClientConfig{CAFile: filepath.Join(t.TempDir(), "does-not-exist.pem")}
+		if _, err := cfg.buildHTTPClient(); err == nil {
+			t.Fatal("buildHTTPClient() = nil error, want an error for a missing CAFile")
+		}
+	})
+
+	t.Run("CA file with no usable certs errors", func(t *testing.T) {
+		dir := t.TempDir()
+		caFile := filepath.Join(dir, "ca.pem")
+		if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+
+		cfg := ClientConfig{CAFile: caFile}
+		if _, err := cfg.buildHTTPClient(); err == nil {
+			t.Fatal("buildHTTPClient() = nil error, want an error for an unparsable CAFile")
+		}
+	})
+
+	t.Run("invalid cert/key pair errors", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile := filepath.Join(dir, "cert.pem")
+		keyFile := filepath.Join(dir, "key.pem")
+		if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+			t.Fatalf("WriteFile cert: %v", err)
+		}
+		if err := os.WriteFile(keyFile, []byte("not a key"), 0o600); err != nil {
+			t.Fatalf("WriteFile key: %v", err)
+		}
+
+		cfg := ClientConfig{CertFile: certFile, KeyFile: keyFile}
+		if _, err := cfg.buildHTTPClient(); err == nil {
+			t.Fatal("buildHTTPClient() = nil error, want an error for an invalid cert/key pair")
+		}
+	})
+
+	t.Run("skip verify builds a client without error", func(t *testing.T) {
+		cfg := ClientConfig{SkipVerify: true}
+		client, err := cfg.buildHTTPClient()
+		if err != nil {
+			t.Fatalf("buildHTTPClient: %v", err)
+		}
+		transport, ok := client.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("Transport = %T, want *http.Transport", client.Transport)
+		}
+		if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("TLSClientConfig.InsecureSkipVerify = false, want true")
+		}
+	})
+
+	t.Run("bad proxy url errors", func(t *testing.T) {
+		cfg := ClientConfig{ProxyURL: "://not-a-url"}
+		if _, err := cfg.buildHTTPClient(); err == nil {
+			t.Fatal("buildHTTPClient() = nil error, want an error for an unparsable proxy URL")
+		}
+	})
+
+	t.Run("valid proxy url is wired into the transport", func(t *testing.T) {
+		cfg := ClientConfig{ProxyURL: "http://proxy.example.com:8080"}
+		client, err := cfg.buildHTTPClient()
+		if err != nil {
+			t.Fatalf("buildHTTPClient: %v", err)
+		}
+		transport, ok := client.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("Transport = %T, want *http.Transport", client.Transport)
+		}
+		if transport.Proxy == nil {
+			t.Error("Transport.Proxy is nil, want the configured proxy func")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package langsmithgo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewClientRequiresAPIKey(t *testing.T) {
+	t.Setenv("LANGSMITH_API_KEY", "")
+	if _, err := NewClient("proj"); err == nil {
+		t.Fatal("NewClient() = nil error, want an error when LANGSMITH_API_KEY is unset")
+	}
+}
+
+func TestNewClientAppliesOptions(t *testing.T) {
+	t.Setenv("LANGSMITH_API_KEY", "test-key")
+	t.Setenv("LANGSMITH_URL", "")
+
+	client, err := NewClient("proj", WithBaseURL("https://self-hosted.example.com/api/v1"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.APIKey != "test-key" {
+		t.Errorf("APIKey = %q, want %q", client.APIKey, "test-key")
+	}
+	if client.baseUrl != "https://self-hosted.example.com/api/v1" {
+		t.Errorf("baseUrl = %q, want the WithBaseURL override", client.baseUrl)
+	}
+}
+
+func TestNewClientRejectsInvalidConfig(t *testing.T) {
+	t.Setenv("LANGSMITH_API_KEY", "test-key")
+	if _, err := NewClient("proj", WithProxy("://not-a-url")); err == nil {
+		t.Fatal("NewClient() = nil error, want validate() to reject an unparsable proxy URL")
+	}
+}
+
+func TestDeadlineTimerArmCancelsContextAtDeadline(t *testing.T) {
+	ctx, d := newDeadlineTimer(context.Background())
+	defer d.Stop()
+
+	if err := d.SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatalf("SetReadDeadline: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("ctx was not cancelled within 1s of an armed 10ms deadline")
+	}
+}
+
+func TestDeadlineTimerStopPreventsCancellation(t *testing.T) {
+	ctx, d := newDeadlineTimer(context.Background())
+
+	if err := d.SetWriteDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+		t.Fatalf("SetWriteDeadline: %v", err)
+	}
+	d.Stop()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("ctx was cancelled after Stop(), want it to stay alive")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDeadlineTimerArmWithZeroTimeDisarms(t *testing.T) {
+	ctx, d := newDeadlineTimer(context.Background())
+	defer d.Stop()
+
+	if err := d.SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatalf("SetReadDeadline: %v", err)
+	}
+	if err := d.arm(time.Time{}); err != nil {
+		t.Fatalf("arm(zero time): %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("ctx was cancelled despite re-arming with a zero time to disarm the timer")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"time"
 )
 
-const (
-	BASE_URL = "https://api.smith.langchain.com/api/v1"
-)
+// BASE_URL is the default LangSmith API base URL used by every method that
+// doesn't take an explicit base (e.g. the examples endpoints, which live at
+// the top level rather than under a DatasetClient's /datasets path). It's a
+// var rather than a const so tests can point it at an httptest server.
+var BASE_URL = "https://api.smith.langchain.com/api/v1"
 
 type Response struct {
 	Detail string `json:"detail"`
@@ -47,8 +50,11 @@ type Client struct {
 }
 
 type baseClient struct {
-	APIKey  string // API key for LangSmith
-	baseUrl string // base url for the LangSmith API
+	APIKey      string       // API key for LangSmith
+	baseUrl     string       // base url for the LangSmith API
+	httpClient  *http.Client // transport used for requests; defaults to http.DefaultClient
+	retryPolicy *RetryPolicy // retry behavior for do; nil means DefaultRetryPolicy
+	traceHooks  *TraceHooks  // optional request/response instrumentation
 }
 
 type SimplePayload struct {
@@ -80,6 +86,9 @@ type PostPayload struct {
 	Extras             map[string]interface{} `json:"extra,omitempty"`
 	Events             []Event                `json:"events,omitempty"`
 	ReferenceExampleID string                 `json:"reference_example_id,omitempty"`
+	Outputs            map[string]interface{} `json:"outputs,omitempty"`
+	EndTime            time.Time              `json:"end_time,omitempty"`
+	Error              string                 `json:"error,omitempty"`
 }
 
 type PatchPayload struct {
@@ -2,18 +2,38 @@ package langsmithgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
 )
 
 type DatasetClient struct {
 	baseClient
 }
 
+// ClientOptions configures NewDatasetClientWithOptions. Any zero-valued
+// field falls back to the same default NewDatasetClient uses.
+type ClientOptions struct {
+	// HTTPClient, if set, is used as-is instead of building one from Timeout.
+	HTTPClient *http.Client
+	// Timeout bounds each request's total round trip when HTTPClient is nil.
+	Timeout time.Duration
+	// BaseURL overrides LANGSMITH_URL/BASE_URL, for self-hosted deployments.
+	BaseURL string
+	// RetryPolicy controls retries on transient failures; nil defaults to
+	// DefaultRetryPolicy(). Pass &RetryPolicy{MaxRetries: 0} explicitly to
+	// disable retries rather than leaving this unset.
+	RetryPolicy *RetryPolicy
+	// TraceHooks, if set, is called around every request/response for
+	// diagnosing API failures.
+	TraceHooks *TraceHooks
+}
+
 // NewDatasetClient creates a new LangSmith client
 // The client requires an API key to authenticate requests.
 // You can get an API key by signing up for a LangSmith account at https://smith.langchain.com
@@ -37,14 +57,44 @@ func NewDatasetClient() (*DatasetClient, error) {
 	}, nil
 }
 
+// NewDatasetClientWithOptions creates a DatasetClient with an injectable
+// *http.Client, timeout, base URL and retry policy, for callers who need
+// more control than the environment-variable-only NewDatasetClient offers.
+func NewDatasetClientWithOptions(opts ClientOptions) (*DatasetClient, error) {
+	if os.Getenv("LANGSMITH_API_KEY") == "" {
+		return nil, errors.New("langsmith api key is required")
+	}
+
+	baseUrl := opts.BaseURL
+	if baseUrl == "" {
+		baseUrl = os.Getenv("LANGSMITH_URL")
+	}
+	if baseUrl == "" {
+		baseUrl = BASE_URL
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: opts.Timeout}
+	}
+
+	return &DatasetClient{
+		baseClient: baseClient{
+			APIKey:      os.Getenv("LANGSMITH_API_KEY"),
+			baseUrl:     fmt.Sprintf("%s/datasets", baseUrl),
+			httpClient:  httpClient,
+			retryPolicy: opts.RetryPolicy,
+			traceHooks:  opts.TraceHooks,
+		},
+	}, nil
+}
+
 func (d *DatasetClient) CreateDataset(input *Dataset) error {
 	jsonData, err := json.Marshal(input)
 	if err != nil {
 		return err
 	}
-	err = d.Do(d.baseUrl, http.MethodPost, jsonData)
-
-	return err
+	return d.doWithContext(context.Background(), http.MethodPost, d.baseUrl, jsonData)
 }
 
 func (d *DatasetClient) UploadCSV(input *DatasetCSV) error {
@@ -59,9 +109,7 @@ func (d *DatasetClient) UploadCSV(input *DatasetCSV) error {
 		return err
 	}
 
-	err = d.PostForm(d.baseUrl+"/upload", &b, contentType)
-
-	return err
+	return d.postForm(context.Background(), d.baseUrl+"/upload", &b, contentType)
 }
 
 func (d *DatasetClient) UploadExperiment(input *Experiment) error {
@@ -69,94 +117,56 @@ func (d *DatasetClient) UploadExperiment(input *Experiment) error {
 	if err != nil {
 		return err
 	}
-	err = d.Do(d.baseUrl+"/upload-experiment", http.MethodPost, jsonData)
-
-	return err
+	return d.doWithContext(context.Background(), http.MethodPost, d.baseUrl+"/upload-experiment", jsonData)
 }
 
-func (d *DatasetClient) ReadDataset(id string) ([]byte, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", d.baseUrl, id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Set the necessary headers
-	req.Header.Set("x-api-key", d.APIKey)
-
-	// Create an HTTP client and send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	err = handleResponse(resp)
-	if err != nil {
-		return nil, err
-	}
-
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	return b, nil
+// ReadDataset fetches the dataset identified by id.
+func (d *DatasetClient) ReadDataset(id string) (*Dataset, error) {
+	return d.ReadDatasetWithContext(context.Background(), id)
 }
 
-func (d *DatasetClient) DownloadDatasetCsv(id string) ([]byte, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/csv", d.baseUrl, id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Set the necessary headers
-	req.Header.Set("x-api-key", d.APIKey)
-
-	fmt.Println(req)
-
-	// Create an HTTP client and send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	err = handleResponse(resp)
-	if err != nil {
+// ReadDatasetWithContext is ReadDataset, bound to ctx and retried per the
+// client's RetryPolicy.
+func (d *DatasetClient) ReadDatasetWithContext(ctx context.Context, id string) (*Dataset, error) {
+	var dataset Dataset
+	if err := d.getJSON(ctx, fmt.Sprintf("%s/%s", d.baseUrl, id), &dataset); err != nil {
 		return nil, err
 	}
+	return &dataset, nil
+}
 
-	var b bytes.Buffer
-
-	_, err = io.Copy(&b, resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// DownloadDatasetCsv streams the dataset identified by id as CSV. The
+// caller must close the returned ReadCloser.
+func (d *DatasetClient) DownloadDatasetCsv(id string) (io.ReadCloser, error) {
+	return d.DownloadDatasetCsvWithContext(context.Background(), id)
+}
 
-	return b.Bytes(), nil
+// DownloadDatasetCsvWithContext is DownloadDatasetCsv, bound to ctx and
+// retried per the client's RetryPolicy.
+func (d *DatasetClient) DownloadDatasetCsvWithContext(ctx context.Context, id string) (io.ReadCloser, error) {
+	return d.getRaw(ctx, fmt.Sprintf("%s/%s/csv", d.baseUrl, id))
 }
 
 func (d *DatasetClient) GetExamples(datasetId string, offset int) ([]Example, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/examples?dataset=%s&offset=%d", BASE_URL, datasetId, offset), nil)
+	return d.GetExamplesWithContext(context.Background(), datasetId, offset)
+}
+
+// GetExamplesWithContext is GetExamples, bound to ctx and retried per the
+// client's RetryPolicy.
+func (d *DatasetClient) GetExamplesWithContext(ctx context.Context, datasetId string, offset int) ([]Example, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/examples?dataset=%s&offset=%d", BASE_URL, datasetId, offset), nil)
 	if err != nil {
 		return nil, err
 	}
-
-	// Set the necessary headers
 	req.Header.Set("x-api-key", d.APIKey)
 
-	// Create an HTTP client and send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	err = handleResponse(resp)
-	if err != nil {
+	if err := handleResponse(resp); err != nil {
 		return nil, err
 	}
 
@@ -166,8 +176,7 @@ func (d *DatasetClient) GetExamples(datasetId string, offset int) ([]Example, er
 	}
 
 	examples := []Example{}
-	err = json.Unmarshal(b, &examples)
-	if err != nil {
+	if err := json.Unmarshal(b, &examples); err != nil {
 		return nil, err
 	}
 	return examples, nil
@@ -178,10 +187,21 @@ func (d *DatasetClient) CreateExample(example Example) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(jsonData))
-	err = d.Do(BASE_URL+"/examples", http.MethodPost, jsonData)
 
-	return err
+	req, err := http.NewRequest(http.MethodPost, BASE_URL+"/examples", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", d.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return handleResponse(resp)
 }
 
 func (d *DatasetClient) CreateExamples(examples []Example) error {
@@ -190,12 +210,16 @@ func (d *DatasetClient) CreateExamples(examples []Example) error {
 		return err
 	}
 
-	err = d.Do(BASE_URL+"/examples/bulk", http.MethodPost, jsonData)
-
-	return err
+	return d.doWithContext(context.Background(), http.MethodPost, BASE_URL+"/examples/bulk", jsonData)
 }
 
 func (d *DatasetClient) GetExamplesWithRuns(datasetId string) ([]Example, error) {
+	return d.GetExamplesWithRunsWithContext(context.Background(), datasetId)
+}
+
+// GetExamplesWithRunsWithContext is GetExamplesWithRuns, bound to ctx and
+// retried per the client's RetryPolicy.
+func (d *DatasetClient) GetExamplesWithRunsWithContext(ctx context.Context, datasetId string) ([]Example, error) {
 	body := map[string]any{
 		"session_ids": []string{"58a7c5a2-c14e-42dc-936a-3af8e84777fa"},
 	}
@@ -205,24 +229,19 @@ func (d *DatasetClient) GetExamplesWithRuns(datasetId string) ([]Example, error)
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/runs", d.baseUrl, datasetId), bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/runs", d.baseUrl, datasetId), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
-
-	// Set the necessary headers
 	req.Header.Set("x-api-key", d.APIKey)
 
-	// Create an HTTP client and send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	err = handleResponse(resp)
-	if err != nil {
+	if err := handleResponse(resp); err != nil {
 		return nil, err
 	}
 
@@ -232,8 +251,7 @@ func (d *DatasetClient) GetExamplesWithRuns(datasetId string) ([]Example, error)
 	}
 
 	examples := []Example{}
-	err = json.Unmarshal(b, &examples)
-	if err != nil {
+	if err := json.Unmarshal(b, &examples); err != nil {
 		return nil, err
 	}
 
@@ -245,30 +263,29 @@ func (d *DatasetClient) CreateComparativeExperiment(input *ComparativeExperiment
 	if err != nil {
 		return err
 	}
-	err = d.Do(d.baseUrl+"/comparative", http.MethodPost, jsonData)
-
-	return err
+	return d.doWithContext(context.Background(), http.MethodPost, d.baseUrl+"/comparative", jsonData)
 }
 
 func (d *DatasetClient) ReadComparitiveExperiment(datasetId, experimentId string) ([]ComparativeExperiment, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/comparative?id=%s", d.baseUrl, datasetId, experimentId), nil)
+	return d.ReadComparitiveExperimentWithContext(context.Background(), datasetId, experimentId)
+}
+
+// ReadComparitiveExperimentWithContext is ReadComparitiveExperiment, bound
+// to ctx and retried per the client's RetryPolicy.
+func (d *DatasetClient) ReadComparitiveExperimentWithContext(ctx context.Context, datasetId, experimentId string) ([]ComparativeExperiment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s/comparative?id=%s", d.baseUrl, datasetId, experimentId), nil)
 	if err != nil {
 		return nil, err
 	}
-
-	// Set the necessary headers
 	req.Header.Set("x-api-key", d.APIKey)
 
-	// Create an HTTP client and send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	err = handleResponse(resp)
-	if err != nil {
+	if err := handleResponse(resp); err != nil {
 		return nil, err
 	}
 
@@ -278,43 +295,52 @@ func (d *DatasetClient) ReadComparitiveExperiment(datasetId, experimentId string
 	}
 
 	var experiments []ComparativeExperiment
-	err = json.Unmarshal(b, &experiments)
-	if err != nil {
+	if err := json.Unmarshal(b, &experiments); err != nil {
 		return nil, err
 	}
 	return experiments, nil
 }
 
 func (d *DatasetClient) QueryRuns(experimentIds []string, isRoot bool, queryParams *QueryParams) (*RunsResponse, error) {
+	return d.QueryRunsWithContext(context.Background(), experimentIds, isRoot, queryParams)
+}
+
+// QueryRunsWithContext is QueryRuns, bound to ctx and retried per the
+// client's RetryPolicy.
+func (d *DatasetClient) QueryRunsWithContext(ctx context.Context, experimentIds []string, isRoot bool, queryParams *QueryParams) (*RunsResponse, error) {
 	body := map[string]any{
 		"session": experimentIds,
 		"root":    isRoot,
 		"select":  queryParams.Select,
 		"filter":  queryParams.Filter,
 	}
+	if queryParams.Limit > 0 {
+		body["limit"] = queryParams.Limit
+	}
+	if queryParams.Offset > 0 {
+		body["offset"] = queryParams.Offset
+	}
+	if queryParams.Order != "" {
+		body["order_by"] = queryParams.Order
+	}
 	data, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/runs/query", BASE_URL), bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/runs/query", BASE_URL), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
-
-	// Set the necessary headers
 	req.Header.Set("x-api-key", d.APIKey)
 
-	// Create an HTTP client and send the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	err = handleResponse(resp)
-	if err != nil {
+	if err := handleResponse(resp); err != nil {
 		return nil, err
 	}
 
@@ -338,7 +364,7 @@ func (d *DatasetClient) CreateFeedback(input *Feedback) error {
 		return err
 	}
 
-	return d.Do(BASE_URL+"/feedback", http.MethodPost, jsonData)
+	return d.doWithContext(context.Background(), http.MethodPost, BASE_URL+"/feedback", jsonData)
 }
 
 func (d *DatasetClient) CreateTracerSession(input *TracerSessionRequest) error {
@@ -347,7 +373,7 @@ func (d *DatasetClient) CreateTracerSession(input *TracerSessionRequest) error {
 		return err
 	}
 
-	return d.Do(BASE_URL+"/sessions", http.MethodPost, jsonData)
+	return d.doWithContext(context.Background(), http.MethodPost, BASE_URL+"/sessions", jsonData)
 }
 
 func (d *DatasetClient) UpdateTracerSession(sessionId string, input *TracerSessionUpdate) error {
@@ -356,5 +382,5 @@ func (d *DatasetClient) UpdateTracerSession(sessionId string, input *TracerSessi
 		return err
 	}
 
-	return d.Do(BASE_URL+"/sessions/"+sessionId, http.MethodPatch, jsonData)
+	return d.doWithContext(context.Background(), http.MethodPatch, BASE_URL+"/sessions/"+sessionId, jsonData)
 }
@@ -0,0 +1,305 @@
+package langsmithgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sync"
+)
+
+// BatchOptions configures CreateExamplesBatched.
+type BatchOptions struct {
+	// ChunkSize is the number of examples sent per request. Defaults to 100.
+	ChunkSize int
+	// Concurrency is the number of chunks uploaded in parallel. Defaults to 1.
+	Concurrency int
+}
+
+// BatchFailure records a chunk that failed to upload in CreateExamplesBatched.
+type BatchFailure struct {
+	StartIndex int
+	Count      int
+	Err        error
+}
+
+// BatchResult is the outcome of CreateExamplesBatched: any chunk that
+// failed is recorded in Failures rather than aborting the whole batch.
+type BatchResult struct {
+	Failures []BatchFailure
+}
+
+// CreateExamplesBatched splits examples into chunks of opts.ChunkSize and
+// uploads them with opts.Concurrency workers, so imports too large for a
+// single /examples/bulk request succeed in pieces. A failing chunk is
+// recorded in the result instead of aborting the rest of the batch.
+func (d *DatasetClient) CreateExamplesBatched(ctx context.Context, examples []Example, opts BatchOptions) (*BatchResult, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 100
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	type chunk struct {
+		start    int
+		examples []Example
+	}
+	var chunks []chunk
+	for start := 0; start < len(examples); start += chunkSize {
+		end := start + chunkSize
+		if end > len(examples) {
+			end = len(examples)
+		}
+		chunks = append(chunks, chunk{start: start, examples: examples[start:end]})
+	}
+
+	result := &BatchResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, c := range chunks {
+		c := c
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := d.createExamplesChunk(ctx, c.examples); err != nil {
+				mu.Lock()
+				result.Failures = append(result.Failures, BatchFailure{StartIndex: c.start, Count: len(c.examples), Err: err})
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+func (d *DatasetClient) createExamplesChunk(ctx context.Context, examples []Example) error {
+	jsonData, err := json.Marshal(examples)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, BASE_URL+"/examples/bulk", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", d.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return handleResponse(resp)
+}
+
+// UploadCSVStreamingOptions configures UploadCSVStreaming.
+type UploadCSVStreamingOptions struct {
+	// RowsPerChunk is the number of CSV data rows uploaded per request.
+	// Defaults to 500.
+	RowsPerChunk int
+	InputKeys    []string
+	OutputKeys   []string
+	Name         string
+	DataType     DataType
+	Description  string
+}
+
+// UploadCSVStreaming uploads the CSV read from r in chunks of
+// opts.RowsPerChunk rows instead of buffering the whole file in memory the
+// way UploadCSV's bytes.Buffer does, which OOMs on multi-GB CSVs. Only the
+// first chunk hits the /upload endpoint that creates the dataset; every
+// later chunk appends its rows to that same dataset as examples, so a
+// multi-chunk CSV still lands as one dataset instead of one per chunk.
+func (d *DatasetClient) UploadCSVStreaming(ctx context.Context, r io.Reader, opts UploadCSVStreamingOptions) error {
+	rowsPerChunk := opts.RowsPerChunk
+	if rowsPerChunk <= 0 {
+		rowsPerChunk = 500
+	}
+
+	csvReader := csv.NewReader(r)
+	header, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	var datasetID string
+	first := true
+	for {
+		rows, readErr := readCSVRows(csvReader, rowsPerChunk)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+
+		if len(rows) > 0 {
+			if first {
+				id, err := d.uploadCSVChunk(ctx, header, rows, opts)
+				if err != nil {
+					return err
+				}
+				datasetID = id
+				first = false
+			} else {
+				if err := d.appendCSVRows(ctx, datasetID, header, rows, opts); err != nil {
+					return err
+				}
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// appendCSVRows converts rows into Examples keyed by opts.InputKeys and
+// opts.OutputKeys and adds them to datasetID, since /upload only ever
+// creates a new dataset rather than appending to one that already exists.
+func (d *DatasetClient) appendCSVRows(ctx context.Context, datasetID string, header []string, rows [][]string, opts UploadCSVStreamingOptions) error {
+	examples := rowsToExamples(datasetID, header, rows, opts)
+
+	jsonData, err := json.Marshal(examples)
+	if err != nil {
+		return err
+	}
+
+	return d.doWithContext(ctx, http.MethodPost, BASE_URL+"/examples/bulk", jsonData)
+}
+
+// rowsToExamples maps each CSV row to an Example, pulling opts.InputKeys
+// into Inputs and opts.OutputKeys into Outputs by column name.
+func rowsToExamples(datasetID string, header []string, rows [][]string, opts UploadCSVStreamingOptions) []Example {
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[name] = i
+	}
+
+	examples := make([]Example, 0, len(rows))
+	for _, row := range rows {
+		inputs := make(map[string]any, len(opts.InputKeys))
+		for _, key := range opts.InputKeys {
+			if i, ok := col[key]; ok && i < len(row) {
+				inputs[key] = row[i]
+			}
+		}
+		outputs := make(map[string]any, len(opts.OutputKeys))
+		for _, key := range opts.OutputKeys {
+			if i, ok := col[key]; ok && i < len(row) {
+				outputs[key] = row[i]
+			}
+		}
+		examples = append(examples, Example{DatasetID: datasetID, Inputs: inputs, Outputs: outputs})
+	}
+	return examples
+}
+
+func readCSVRows(r *csv.Reader, n int) ([][]string, error) {
+	rows := make([][]string, 0, n)
+	for i := 0; i < n; i++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows, io.EOF
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// uploadCSVChunk builds header+rows into an in-memory multipart body and
+// POSTs it to /upload. Chunks are already bounded by RowsPerChunk, so
+// buffering one chunk doesn't reintroduce the whole-file-in-memory problem
+// UploadCSVStreaming exists to avoid - and, unlike an io.Pipe body, a
+// *bytes.Reader over that buffer lets http.NewRequestWithContext populate
+// req.GetBody, so
+// d.do can actually replay the body if a retry is needed. It returns the ID
+// of the dataset the upload created.
+func (d *DatasetClient) uploadCSVChunk(ctx context.Context, header []string, rows [][]string, opts UploadCSVStreamingOptions) (string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fw, err := mw.CreateFormFile("file", "dataset.csv")
+	if err != nil {
+		return "", err
+	}
+
+	csvWriter := csv.NewWriter(fw)
+	if err := csvWriter.Write(header); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		if err := csvWriter.Write(row); err != nil {
+			return "", err
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return "", err
+	}
+
+	for _, key := range opts.InputKeys {
+		if err := mw.WriteField("input_keys", key); err != nil {
+			return "", err
+		}
+	}
+	if opts.Name != "" {
+		if err := mw.WriteField("name", opts.Name); err != nil {
+			return "", err
+		}
+	}
+	if err := mw.WriteField("data_type", string(opts.DataType)); err != nil {
+		return "", err
+	}
+	for _, key := range opts.OutputKeys {
+		if err := mw.WriteField("output_keys", key); err != nil {
+			return "", err
+		}
+	}
+	if opts.Description != "" {
+		if err := mw.WriteField("description", opts.Description); err != nil {
+			return "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.baseUrl+"/upload", bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("x-api-key", d.APIKey)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := d.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return "", err
+	}
+
+	var dataset Dataset
+	if err := json.NewDecoder(resp.Body).Decode(&dataset); err != nil {
+		return "", err
+	}
+	return dataset.ID, nil
+}
@@ -0,0 +1,210 @@
+package langsmithgo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func csvReadAll(r io.Reader) ([][]string, error) {
+	return csv.NewReader(r).ReadAll()
+}
+
+func TestRowsToExamples(t *testing.T) {
+	header := []string{"question", "answer", "notes"}
+	rows := [][]string{
+		{"2+2?", "4", "easy"},
+		{"capital of France?", "Paris", "geography"},
+	}
+	opts := UploadCSVStreamingOptions{
+		InputKeys:  []string{"question"},
+		OutputKeys: []string{"answer"},
+	}
+
+	examples := rowsToExamples("dataset-123", header, rows, opts)
+	if len(examples) != 2 {
+		t.Fatalf("len(examples) = %d, want 2", len(examples))
+	}
+
+	for i, ex := range examples {
+		if ex.DatasetID != "dataset-123" {
+			t.Errorf("examples[%d].DatasetID = %q, want %q", i, ex.DatasetID, "dataset-123")
+		}
+		if _, ok := ex.Inputs["question"]; !ok {
+			t.Errorf("examples[%d].Inputs missing %q: %v", i, "question", ex.Inputs)
+		}
+		if _, ok := ex.Outputs["answer"]; !ok {
+			t.Errorf("examples[%d].Outputs missing %q: %v", i, "answer", ex.Outputs)
+		}
+		if _, ok := ex.Inputs["notes"]; ok {
+			t.Errorf("examples[%d].Inputs should not contain columns outside InputKeys, got %v", i, ex.Inputs)
+		}
+	}
+
+	if examples[0].Inputs["question"] != "2+2?" || examples[0].Outputs["answer"] != "4" {
+		t.Errorf("examples[0] = %+v, want question=2+2? answer=4", examples[0])
+	}
+}
+
+func TestUploadCSVChunkReturnsCreatedDatasetID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/upload" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Dataset{ID: "ds-created"})
+	}))
+	defer srv.Close()
+
+	d := &DatasetClient{baseClient: baseClient{APIKey: "key", baseUrl: srv.URL}}
+
+	id, err := d.uploadCSVChunk(
+		context.Background(),
+		[]string{"question", "answer"},
+		[][]string{{"2+2?", "4"}},
+		UploadCSVStreamingOptions{InputKeys: []string{"question"}, OutputKeys: []string{"answer"}},
+	)
+	if err != nil {
+		t.Fatalf("uploadCSVChunk: %v", err)
+	}
+	if id != "ds-created" {
+		t.Errorf("dataset id = %q, want %q", id, "ds-created")
+	}
+}
+
+// TestUploadCSVChunkRetriesWithFullBodyOnTransientError asserts that a 500
+// on the first attempt gets retried with the complete multipart body
+// (header + every row), not an empty or partially-drained one - the
+// in-memory buffer uploadCSVChunk builds its request body from must
+// populate req.GetBody so baseClient.do can replay it.
+func TestUploadCSVChunkRetriesWithFullBodyOnTransientError(t *testing.T) {
+	var attempts int
+	var rowsSeen [][]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("parse content type: %v", err)
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			if part.FormName() != "file" {
+				continue
+			}
+			rows, err := csvReadAll(part)
+			if err != nil {
+				t.Fatalf("read csv part: %v", err)
+			}
+			rowsSeen = rows
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Dataset{ID: "ds-created"})
+	}))
+	defer srv.Close()
+
+	fast := RetryPolicy{MaxRetries: 1, MinWait: time.Millisecond, MaxWait: time.Millisecond, RetryableStatuses: []int{http.StatusInternalServerError}}
+	d := &DatasetClient{baseClient: baseClient{APIKey: "key", baseUrl: srv.URL, retryPolicy: &fast}}
+
+	header := []string{"question", "answer"}
+	rows := [][]string{{"2+2?", "4"}, {"3+3?", "6"}}
+
+	id, err := d.uploadCSVChunk(context.Background(), header, rows, UploadCSVStreamingOptions{InputKeys: []string{"question"}, OutputKeys: []string{"answer"}})
+	if err != nil {
+		t.Fatalf("uploadCSVChunk: %v", err)
+	}
+	if id != "ds-created" {
+		t.Errorf("dataset id = %q, want %q", id, "ds-created")
+	}
+	if attempts != 2 {
+		t.Fatalf("attempts = %d, want 2", attempts)
+	}
+
+	want := append([][]string{header}, rows...)
+	if len(rowsSeen) != len(want) {
+		t.Fatalf("retried request carried %d CSV rows, want %d (full body, not drained/empty)", len(rowsSeen), len(want))
+	}
+	for i := range want {
+		if len(rowsSeen[i]) != len(want[i]) || rowsSeen[i][0] != want[i][0] {
+			t.Errorf("row %d = %v, want %v", i, rowsSeen[i], want[i])
+		}
+	}
+}
+
+// TestUploadCSVStreamingThreadsDatasetIDAcrossChunks drives two chunks
+// through UploadCSVStreaming and asserts the second chunk appends to the
+// dataset the first chunk created instead of creating its own dataset.
+func TestUploadCSVStreamingThreadsDatasetIDAcrossChunks(t *testing.T) {
+	var uploadCalls, bulkCalls int
+	var bulkDatasetIDs []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/upload":
+			uploadCalls++
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Dataset{ID: "ds-created"})
+		case "/examples/bulk":
+			bulkCalls++
+			var examples []Example
+			if err := json.NewDecoder(r.Body).Decode(&examples); err != nil {
+				t.Errorf("decode bulk body: %v", err)
+			}
+			for _, ex := range examples {
+				bulkDatasetIDs = append(bulkDatasetIDs, ex.DatasetID)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	restoreBaseURL := BASE_URL
+	BASE_URL = srv.URL
+	defer func() { BASE_URL = restoreBaseURL }()
+
+	d := &DatasetClient{baseClient: baseClient{APIKey: "key", baseUrl: srv.URL}}
+
+	csvBody := "question,answer\nq1,a1\nq2,a2\nq3,a3\n"
+	opts := UploadCSVStreamingOptions{
+		RowsPerChunk: 2,
+		InputKeys:    []string{"question"},
+		OutputKeys:   []string{"answer"},
+	}
+
+	if err := d.UploadCSVStreaming(context.Background(), strings.NewReader(csvBody), opts); err != nil {
+		t.Fatalf("UploadCSVStreaming: %v", err)
+	}
+
+	if uploadCalls != 1 {
+		t.Errorf("uploadCalls = %d, want 1 (only the first chunk should create a dataset)", uploadCalls)
+	}
+	if bulkCalls != 1 {
+		t.Errorf("bulkCalls = %d, want 1 (the remaining rows should append as examples)", bulkCalls)
+	}
+	for _, id := range bulkDatasetIDs {
+		if id != "ds-created" {
+			t.Errorf("appended example has DatasetID %q, want %q (the dataset the first chunk created)", id, "ds-created")
+		}
+	}
+}
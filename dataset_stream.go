@@ -0,0 +1,227 @@
+package langsmithgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ExampleResult is one item pushed onto the channel returned by
+// StreamExamples: either a decoded Example, or an Err if the underlying
+// page fetch failed.
+type ExampleResult struct {
+	Example Example
+	Err     error
+}
+
+// StreamOptions configures StreamExamples and StreamRuns.
+type StreamOptions struct {
+	// PageSize is the number of items requested per page. Defaults to 100.
+	PageSize int
+	// Concurrency, when > 1, prefetches the next page while the caller
+	// drains the current one instead of fetching strictly page-by-page.
+	Concurrency int
+	// Filter, if set, drops examples for which it returns false before they
+	// reach the channel.
+	Filter func(Example) bool
+}
+
+// StreamExamples returns a channel of every example in datasetId, fetching
+// pages in the background as the caller drains the channel. The channel is
+// closed once all pages are delivered, ctx is cancelled, or a page fetch
+// fails (in which case the final value carries the error).
+func (d *DatasetClient) StreamExamples(ctx context.Context, datasetId string, opts *StreamOptions) (<-chan ExampleResult, error) {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	out := make(chan ExampleResult)
+
+	go func() {
+		defer close(out)
+
+		type page struct {
+			examples []Example
+			err      error
+		}
+		fetch := func(offset int) <-chan page {
+			ch := make(chan page, 1)
+			go func() {
+				examples, err := d.getExamplesPage(ctx, datasetId, offset, pageSize)
+				ch <- page{examples, err}
+			}()
+			return ch
+		}
+
+		offset := 0
+		next := fetch(offset)
+
+		for {
+			select {
+			case <-ctx.Done():
+				// ctx is why a caller cancels, so it's typically also why
+				// they've stopped draining out; don't risk blocking here.
+				return
+			case p := <-next:
+				if p.err != nil {
+					out <- ExampleResult{Err: p.err}
+					return
+				}
+
+				full := len(p.examples) == pageSize
+				if full && opts.Concurrency > 1 {
+					next = fetch(offset + len(p.examples))
+				}
+
+				for _, example := range p.examples {
+					if opts.Filter != nil && !opts.Filter(example) {
+						continue
+					}
+					select {
+					case out <- ExampleResult{Example: example}:
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				if !full {
+					return
+				}
+				offset += len(p.examples)
+				if opts.Concurrency <= 1 {
+					next = fetch(offset)
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (d *DatasetClient) getExamplesPage(ctx context.Context, datasetId string, offset, limit int) ([]Example, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/examples?dataset=%s&offset=%d&limit=%d", BASE_URL, datasetId, offset, limit), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", d.APIKey)
+
+	resp, err := d.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return nil, err
+	}
+
+	examples := []Example{}
+	if err := json.NewDecoder(resp.Body).Decode(&examples); err != nil {
+		return nil, err
+	}
+	return examples, nil
+}
+
+// RunResult is one item pushed onto the channel returned by StreamRuns.
+type RunResult struct {
+	Run Run
+	Err error
+}
+
+// StreamRuns wraps QueryRuns with cursor follow-through, streaming every
+// matching run (e.g. every row of an experiment's results) onto a channel
+// instead of requiring the caller to manage cursors by hand.
+func (d *DatasetClient) StreamRuns(ctx context.Context, experimentIds []string, isRoot bool, queryParams *QueryParams) (<-chan RunResult, error) {
+	out := make(chan RunResult)
+
+	go func() {
+		defer close(out)
+
+		var cursor string
+		for {
+			select {
+			case <-ctx.Done():
+				// ctx is why a caller cancels, so it's typically also why
+				// they've stopped draining out; don't risk blocking here.
+				return
+			default:
+			}
+
+			resp, err := d.queryRunsPage(ctx, experimentIds, isRoot, queryParams, cursor)
+			if err != nil {
+				out <- RunResult{Err: err}
+				return
+			}
+
+			for _, run := range resp.Runs {
+				select {
+				case out <- RunResult{Run: run}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			next, ok := resp.Cursors["next"].(string)
+			if !ok || next == "" {
+				return
+			}
+			cursor = next
+		}
+	}()
+
+	return out, nil
+}
+
+func (d *DatasetClient) queryRunsPage(ctx context.Context, experimentIds []string, isRoot bool, queryParams *QueryParams, cursor string) (*RunsResponse, error) {
+	body := map[string]any{
+		"session": experimentIds,
+		"root":    isRoot,
+		"select":  queryParams.Select,
+		"filter":  queryParams.Filter,
+	}
+	if queryParams.Limit > 0 {
+		body["limit"] = queryParams.Limit
+	}
+	if queryParams.Offset > 0 {
+		body["offset"] = queryParams.Offset
+	}
+	if queryParams.Order != "" {
+		body["order_by"] = queryParams.Order
+	}
+	if cursor != "" {
+		body["cursor"] = cursor
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/runs/query", BASE_URL), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", d.APIKey)
+
+	resp, err := d.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var runsResponse RunsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&runsResponse); err != nil {
+		return nil, err
+	}
+	return &runsResponse, nil
+}
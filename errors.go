@@ -0,0 +1,65 @@
+package langsmithgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError is returned by getJSON/getRaw (and anything built on them) in
+// place of an opaque error, so callers can distinguish a 404 (dataset not
+// found) from a 409 (duplicate) from a 429 (rate limited) with errors.As
+// instead of matching on error strings.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	Raw        []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("langsmithgo: api error (status %d, code %s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// parseAPIError returns nil for a successful response, or an *APIError
+// describing a non-2xx one. It consumes resp.Body; callers still close it.
+func parseAPIError(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+
+	raw, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       codeForStatus(resp.StatusCode),
+		RequestID:  resp.Header.Get("x-request-id"),
+		Raw:        raw,
+	}
+
+	var detail Response
+	if err := json.Unmarshal(raw, &detail); err == nil && detail.Detail != "" {
+		apiErr.Message = detail.Detail
+	} else {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "unauthorized"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,114 @@
+package langsmithgo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FilterExpr renders to a LangSmith filter-expression string. Build one with
+// NewRunFilter and its Eq/Gte/Lte/In/And/Or methods instead of hand-writing
+// the filter DSL LangSmith's /runs/query endpoint expects.
+type FilterExpr struct {
+	expr string
+}
+
+// NewRunFilter returns an empty FilterExpr ready for chaining.
+func NewRunFilter() *FilterExpr {
+	return &FilterExpr{}
+}
+
+// String renders the filter to LangSmith's filter-expression syntax.
+func (f *FilterExpr) String() string {
+	return f.expr
+}
+
+// Eq adds an equality clause, combined with any existing clause via and().
+func (f *FilterExpr) Eq(field string, value any) *FilterExpr {
+	return f.chain(fmt.Sprintf("eq(%s, %s)", field, renderFilterValue(value)))
+}
+
+// Gte adds a greater-than-or-equal clause, combined with any existing
+// clause via and().
+func (f *FilterExpr) Gte(field string, value any) *FilterExpr {
+	return f.chain(fmt.Sprintf("gte(%s, %s)", field, renderFilterValue(value)))
+}
+
+// Lte adds a less-than-or-equal clause, combined with any existing clause
+// via and().
+func (f *FilterExpr) Lte(field string, value any) *FilterExpr {
+	return f.chain(fmt.Sprintf("lte(%s, %s)", field, renderFilterValue(value)))
+}
+
+// In adds a set-membership clause, combined with any existing clause via
+// and().
+func (f *FilterExpr) In(field string, values ...any) *FilterExpr {
+	rendered := make([]string, len(values))
+	for i, v := range values {
+		rendered[i] = renderFilterValue(v)
+	}
+	return f.chain(fmt.Sprintf("in(%s, [%s])", field, strings.Join(rendered, ", ")))
+}
+
+// And combines f with other.
+func (f *FilterExpr) And(other *FilterExpr) *FilterExpr {
+	if f.expr == "" {
+		return other
+	}
+	if other == nil || other.expr == "" {
+		return f
+	}
+	return &FilterExpr{expr: fmt.Sprintf("and(%s, %s)", f.expr, other.expr)}
+}
+
+// Or combines f with other.
+func (f *FilterExpr) Or(other *FilterExpr) *FilterExpr {
+	if f.expr == "" {
+		return other
+	}
+	if other == nil || other.expr == "" {
+		return f
+	}
+	return &FilterExpr{expr: fmt.Sprintf("or(%s, %s)", f.expr, other.expr)}
+}
+
+// chain folds a new clause into f, and()-ing it with whatever f already has.
+func (f *FilterExpr) chain(clause string) *FilterExpr {
+	if f.expr == "" {
+		return &FilterExpr{expr: clause}
+	}
+	return &FilterExpr{expr: fmt.Sprintf("and(%s, %s)", f.expr, clause)}
+}
+
+func renderFilterValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", val)
+	case time.Time:
+		return fmt.Sprintf("%q", val.UTC().Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// RunSelect builds the list of fields a /runs/query call should return,
+// instead of requiring callers to hand-write QueryParams.Select.
+type RunSelect struct {
+	fields []string
+}
+
+// NewRunSelect returns an empty RunSelect ready for chaining.
+func NewRunSelect() *RunSelect {
+	return &RunSelect{}
+}
+
+// Fields appends field names to the selection.
+func (s *RunSelect) Fields(fields ...string) *RunSelect {
+	s.fields = append(s.fields, fields...)
+	return s
+}
+
+// Build returns the selected field names, for assigning to QueryParams.Select.
+func (s *RunSelect) Build() []string {
+	return s.fields
+}
@@ -0,0 +1,82 @@
+package langsmithgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterExprRendering(t *testing.T) {
+	tests := []struct {
+		name string
+		expr *FilterExpr
+		want string
+	}{
+		{
+			name: "eq string",
+			expr: NewRunFilter().Eq("name", "my-run"),
+			want: `eq(name, "my-run")`,
+		},
+		{
+			name: "gte time",
+			expr: NewRunFilter().Gte("start_time", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+			want: `gte(start_time, "2026-01-02T03:04:05Z")`,
+		},
+		{
+			name: "lte number",
+			expr: NewRunFilter().Lte("latency", 2.5),
+			want: `lte(latency, 2.5)`,
+		},
+		{
+			name: "in values",
+			expr: NewRunFilter().In("status", "success", "error"),
+			want: `in(status, ["success", "error"])`,
+		},
+		{
+			name: "chained clauses and()-combine",
+			expr: NewRunFilter().Eq("name", "my-run").Gte("latency", 1),
+			want: `and(eq(name, "my-run"), gte(latency, 1))`,
+		},
+		{
+			name: "explicit And",
+			expr: NewRunFilter().Eq("a", 1).And(NewRunFilter().Eq("b", 2)),
+			want: `and(eq(a, 1), eq(b, 2))`,
+		},
+		{
+			name: "explicit Or",
+			expr: NewRunFilter().Eq("a", 1).Or(NewRunFilter().Eq("b", 2)),
+			want: `or(eq(a, 1), eq(b, 2))`,
+		},
+		{
+			name: "And with empty other returns f unchanged",
+			expr: NewRunFilter().Eq("a", 1).And(NewRunFilter()),
+			want: `eq(a, 1)`,
+		},
+		{
+			name: "And on empty f returns other",
+			expr: NewRunFilter().And(NewRunFilter().Eq("b", 2)),
+			want: `eq(b, 2)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expr.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSelectBuild(t *testing.T) {
+	got := NewRunSelect().Fields("id", "name").Fields("status").Build()
+	want := []string{"id", "name", "status"}
+
+	if len(got) != len(want) {
+		t.Fatalf("Build() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Build()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package langsmithgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// runPaginator holds the cursor-pagination state shared by RunIterator and
+// RunsIterator: both walk a /runs/query-shaped response and differ only in
+// how they fetch a page. advance loops internally on a page that comes back
+// empty but with a valid cursor, instead of treating an empty page as the
+// end of the result set.
+type runPaginator struct {
+	runs        []Run
+	idx         int
+	cursor      string
+	done        bool
+	err         error
+	parsedQuery *string
+}
+
+// advance fetches pages via fetch until it finds a non-empty one, the
+// result set is exhausted, ctx is done, or fetch returns an error.
+func (p *runPaginator) advance(ctx context.Context, fetch func(cursor string) (*RunsResponse, error)) bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.idx++
+	if p.idx < len(p.runs) {
+		return true
+	}
+
+	for !p.done {
+		select {
+		case <-ctx.Done():
+			p.err = ctx.Err()
+			return false
+		default:
+		}
+
+		resp, err := fetch(p.cursor)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.runs = resp.Runs
+		p.parsedQuery = resp.ParsedQuery
+		if next, ok := resp.Cursors["next"].(string); ok && next != "" {
+			p.cursor = next
+		} else {
+			p.done = true
+		}
+
+		p.idx = 0
+		if len(p.runs) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RunIterator walks the pages of a /runs/query call, re-issuing the request
+// with the server's opaque cursor until the results are exhausted.
+//
+// Usage follows the standard Go iterator style:
+//
+//	it := client.IterateRuns(ctx, params)
+//	for it.Next() {
+//		run := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle err
+//	}
+type RunIterator struct {
+	client *Client
+	ctx    context.Context
+	params QueryParams
+
+	p runPaginator
+}
+
+// IterateRuns returns a RunIterator over runs matching params, paginating
+// transparently as the caller advances it with Next.
+func (c *Client) IterateRuns(ctx context.Context, params QueryParams) *RunIterator {
+	it := &RunIterator{client: c, ctx: ctx, params: params}
+	it.p.idx = -1
+	return it
+}
+
+// Next advances the iterator, fetching the next page from the server when
+// the current one is exhausted. It returns false at the end of the result
+// set, when ctx is done, or on a request error (see Err).
+func (it *RunIterator) Next() bool {
+	return it.p.advance(it.ctx, it.fetchPage)
+}
+
+// Value returns the run at the iterator's current position. Only valid
+// after a call to Next that returned true.
+func (it *RunIterator) Value() Run {
+	return it.p.runs[it.p.idx]
+}
+
+// Err returns the first error encountered while paginating, if any.
+func (it *RunIterator) Err() error {
+	return it.p.err
+}
+
+// ParsedQuery surfaces the server's interpretation of the filter DSL from
+// the most recently fetched page, for debugging a malformed filter string.
+func (it *RunIterator) ParsedQuery() *string {
+	return it.p.parsedQuery
+}
+
+func (it *RunIterator) fetchPage(cursor string) (*RunsResponse, error) {
+	limit := it.params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	body := map[string]any{
+		"select": it.params.Select,
+		"filter": it.params.Filter,
+		"limit":  limit,
+	}
+	if cursor != "" {
+		body["cursor"] = cursor
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(it.ctx, http.MethodPost, it.client.baseUrl+"/runs/query", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", it.client.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := it.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var runsResponse RunsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&runsResponse); err != nil {
+		return nil, err
+	}
+
+	return &runsResponse, nil
+}
+
+// ExampleIterator walks the pages of a dataset's examples, re-issuing the
+// list request with an incrementing offset until a page comes back short of
+// a full page.
+type ExampleIterator struct {
+	client    *Client
+	ctx       context.Context
+	datasetID string
+	params    QueryParams
+
+	examples []Example
+	idx      int
+	offset   int
+	done     bool
+	err      error
+}
+
+// IterateExamples returns an ExampleIterator over datasetID's examples.
+func (c *Client) IterateExamples(ctx context.Context, datasetID string, params QueryParams) *ExampleIterator {
+	return &ExampleIterator{client: c, ctx: ctx, datasetID: datasetID, params: params, idx: -1}
+}
+
+// Next advances the iterator, fetching the next page when the current one
+// is exhausted.
+func (it *ExampleIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.idx++
+	if it.idx < len(it.examples) {
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	select {
+	case <-it.ctx.Done():
+		it.err = it.ctx.Err()
+		return false
+	default:
+	}
+
+	if err := it.fetch(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.idx = 0
+	return len(it.examples) > 0
+}
+
+// Value returns the example at the iterator's current position. Only valid
+// after a call to Next that returned true.
+func (it *ExampleIterator) Value() Example {
+	return it.examples[it.idx]
+}
+
+// Err returns the first error encountered while paginating, if any.
+func (it *ExampleIterator) Err() error {
+	return it.err
+}
+
+func (it *ExampleIterator) fetch() error {
+	limit := it.params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	url := fmt.Sprintf("%s/examples?dataset=%s&offset=%d&limit=%d", it.client.baseUrl, it.datasetID, it.offset, limit)
+
+	req, err := http.NewRequestWithContext(it.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-api-key", it.client.APIKey)
+
+	resp, err := it.client.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return err
+	}
+
+	var examples []Example
+	if err := json.NewDecoder(resp.Body).Decode(&examples); err != nil {
+		return err
+	}
+
+	it.examples = examples
+	it.offset += len(examples)
+	if len(examples) < limit {
+		it.done = true
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package langsmithgo
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRunPaginatorAdvanceSkipsEmptyPageWithCursor asserts that a page with
+// zero runs but a valid "next" cursor doesn't end iteration: advance should
+// keep fetching until it finds a non-empty page or the cursor runs out.
+func TestRunPaginatorAdvanceSkipsEmptyPageWithCursor(t *testing.T) {
+	pages := []*RunsResponse{
+		{Runs: nil, Cursors: map[string]any{"next": "cursor-1"}},
+		{Runs: []Run{{ID: "run-1"}}, Cursors: map[string]any{"next": "cursor-2"}},
+		{Runs: nil, Cursors: map[string]any{}},
+	}
+	call := 0
+	fetch := func(cursor string) (*RunsResponse, error) {
+		resp := pages[call]
+		call++
+		return resp, nil
+	}
+
+	var p runPaginator
+	p.idx = -1
+
+	if !p.advance(context.Background(), fetch) {
+		t.Fatalf("advance() = false, want true (should skip the empty first page and find run-1)")
+	}
+	if p.runs[p.idx].ID != "run-1" {
+		t.Errorf("runs[idx].ID = %q, want %q", p.runs[p.idx].ID, "run-1")
+	}
+
+	if p.advance(context.Background(), fetch) {
+		t.Fatalf("advance() = true, want false once the result set and cursor are exhausted")
+	}
+	if p.err != nil {
+		t.Errorf("err = %v, want nil (exhaustion is not an error)", p.err)
+	}
+	if call != len(pages) {
+		t.Errorf("fetch called %d times, want %d", call, len(pages))
+	}
+}
+
+// TestRunPaginatorAdvanceStopsOnCtxDone asserts advance returns false and
+// records ctx.Err() once the context is cancelled, instead of fetching.
+func TestRunPaginatorAdvanceStopsOnCtxDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	fetch := func(cursor string) (*RunsResponse, error) {
+		called = true
+		return &RunsResponse{}, nil
+	}
+
+	var p runPaginator
+	p.idx = -1
+
+	if p.advance(ctx, fetch) {
+		t.Fatalf("advance() = true, want false on a cancelled ctx")
+	}
+	if p.err != ctx.Err() {
+		t.Errorf("err = %v, want %v", p.err, ctx.Err())
+	}
+	if called {
+		t.Errorf("fetch should not be called once ctx is already done")
+	}
+}
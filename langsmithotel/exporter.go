@@ -0,0 +1,216 @@
+// Package langsmithotel bridges OpenTelemetry tracing into LangSmith by
+// implementing an sdktrace.SpanExporter that maps each span to a LangSmith
+// run.
+package langsmithotel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/amitaifrey/langsmithgo"
+)
+
+// Exporter is an sdktrace.SpanExporter that translates spans into
+// PostPayload/PatchPayload pairs and feeds them to a BatchIngestor, so a
+// single LangSmith ingestor can back both direct run submissions and an
+// OTel-instrumented code path.
+type Exporter struct {
+	ingestor *langsmithgo.BatchIngestor
+
+	mu           sync.Mutex
+	dottedOrders map[string]string // span ID -> dotted order, for ancestor lookups
+}
+
+// NewExporter returns an Exporter that enqueues runs onto ingestor.
+func NewExporter(ingestor *langsmithgo.BatchIngestor) *Exporter {
+	return &Exporter{
+		ingestor:     ingestor,
+		dottedOrders: make(map[string]string),
+	}
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+//
+// Child spans routinely finish - and so get exported - before their parent,
+// so a single call's spans can't be dotted-ordered one at a time in the
+// order they arrive: when a child is processed its parent may not be in
+// e.dottedOrders yet. Dotted orders for this whole batch are resolved up
+// front instead, so a child can walk up to an ancestor that is present
+// anywhere in the same batch, not just one already recorded from a previous
+// call.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	bySpanID := make(map[string]sdktrace.ReadOnlySpan, len(spans))
+	for _, span := range spans {
+		bySpanID[span.SpanContext().SpanID().String()] = span
+	}
+
+	dottedOrders := make(map[string]string, len(spans))
+	e.mu.Lock()
+	for _, span := range spans {
+		e.resolveDottedOrder(span, bySpanID, dottedOrders)
+	}
+	e.mu.Unlock()
+
+	for _, span := range spans {
+		post := e.spanToPost(span, dottedOrders[span.SpanContext().SpanID().String()])
+		if err := e.ingestor.Enqueue(post); err != nil {
+			return err
+		}
+
+		patch := spanToPatch(span)
+		submission := &langsmithgo.PatchSubmission{RunID: post.ID, Patch: patch}
+		if err := e.ingestor.Enqueue(submission); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown implements sdktrace.SpanExporter by draining the ingestor.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.ingestor.Close(ctx)
+}
+
+func (e *Exporter) spanToPost(span sdktrace.ReadOnlySpan, dottedOrder string) *langsmithgo.PostPayload {
+	sc := span.SpanContext()
+	runID := sc.SpanID().String()
+
+	attrs := attributeMap(span.Attributes())
+
+	post := &langsmithgo.PostPayload{
+		ID:        runID,
+		Name:      span.Name(),
+		RunType:   runTypeFor(span, attrs),
+		StartTime: span.StartTime(),
+		Inputs:    inputsFromAttributes(attrs),
+		Extras:    map[string]interface{}{"attributes": attrs},
+	}
+
+	if parent := span.Parent(); parent.HasSpanID() {
+		post.ParentId = parent.SpanID().String()
+	}
+
+	post.Extras["dotted_order"] = dottedOrder
+	post.Extras["trace_id"] = sc.TraceID().String()
+
+	return post
+}
+
+// resolveDottedOrder builds LangSmith's dotted-order string (an ordered
+// chain of "<start-time>Z<run-id>" segments, most distant ancestor first)
+// for span, memoizing the result in both memo (this batch) and
+// e.dottedOrders (across batches, for spans whose children export later).
+// Callers must hold e.mu.
+//
+// A span's parent may not have been exported yet (it's still running), may
+// have been exported in this very batch (bySpanID), or may have been
+// exported in an earlier batch (e.dottedOrders) - resolveDottedOrder checks
+// all three before falling back to treating span as its own root.
+func (e *Exporter) resolveDottedOrder(span sdktrace.ReadOnlySpan, bySpanID map[string]sdktrace.ReadOnlySpan, memo map[string]string) string {
+	spanID := span.SpanContext().SpanID().String()
+	if order, ok := memo[spanID]; ok {
+		return order
+	}
+	if order, ok := e.dottedOrders[spanID]; ok {
+		memo[spanID] = order
+		return order
+	}
+
+	segment := fmt.Sprintf("%sZ%s", span.StartTime().UTC().Format("20060102T150405060000"), spanID)
+
+	dottedOrder := segment
+	if parent := span.Parent(); parent.HasSpanID() {
+		parentID := parent.SpanID().String()
+		if parentOrder, ok := e.dottedOrders[parentID]; ok {
+			dottedOrder = parentOrder + "." + segment
+		} else if parentSpan, ok := bySpanID[parentID]; ok {
+			dottedOrder = e.resolveDottedOrder(parentSpan, bySpanID, memo) + "." + segment
+		}
+	}
+
+	memo[spanID] = dottedOrder
+	e.dottedOrders[spanID] = dottedOrder
+	return dottedOrder
+}
+
+func spanToPatch(span sdktrace.ReadOnlySpan) *langsmithgo.PatchPayload {
+	attrs := attributeMap(span.Attributes())
+
+	patch := &langsmithgo.PatchPayload{
+		Outputs: outputsFromAttributes(attrs),
+		EndTime: span.EndTime(),
+	}
+
+	if status := span.Status(); status.Code == codes.Error {
+		patch.Error = status.Description
+	}
+
+	usage := usageFromAttributes(attrs)
+	if len(usage) > 0 {
+		patch.Extras = map[string]interface{}{"usage_metadata": usage}
+	}
+
+	return patch
+}
+
+// runTypeFor picks a RunType from the span's gen_ai operation name, falling
+// back to its kind.
+func runTypeFor(span sdktrace.ReadOnlySpan, attrs map[string]interface{}) langsmithgo.RunType {
+	switch attrs["gen_ai.operation.name"] {
+	case "chat", "text_completion":
+		return langsmithgo.LLM
+	case "embeddings":
+		return langsmithgo.Embedding
+	case "execute_tool":
+		return langsmithgo.Tool
+	}
+
+	if span.SpanKind().String() == "client" {
+		return langsmithgo.LLM
+	}
+	return langsmithgo.Chain
+}
+
+func inputsFromAttributes(attrs map[string]interface{}) map[string]interface{} {
+	inputs := map[string]interface{}{}
+	for k, v := range attrs {
+		if k == "gen_ai.prompt" || k == "gen_ai.request.model" {
+			inputs[k] = v
+		}
+	}
+	return inputs
+}
+
+func outputsFromAttributes(attrs map[string]interface{}) map[string]interface{} {
+	outputs := map[string]interface{}{}
+	for k, v := range attrs {
+		if k == "gen_ai.completion" || k == "gen_ai.response.model" || k == "gen_ai.response.finish_reasons" {
+			outputs[k] = v
+		}
+	}
+	return outputs
+}
+
+func usageFromAttributes(attrs map[string]interface{}) map[string]interface{} {
+	usage := map[string]interface{}{}
+	if v, ok := attrs["gen_ai.usage.prompt_tokens"]; ok {
+		usage["input_tokens"] = v
+	}
+	if v, ok := attrs["gen_ai.usage.completion_tokens"]; ok {
+		usage["output_tokens"] = v
+	}
+	return usage
+}
+
+func attributeMap(kvs []attribute.KeyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return m
+}
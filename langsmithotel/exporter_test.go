@@ -0,0 +1,106 @@
+package langsmithotel
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/amitaifrey/langsmithgo"
+)
+
+// TestExportSpansChainsDottedOrderAcrossParentAndChild drives a real
+// parent+child span pair through ExportSpans, ending the child first (the
+// common case: a child finishes, and is handed to the exporter, before its
+// still-running parent). It asserts the child's dotted order is chained off
+// the parent's rather than left as a bare, unchained segment.
+func TestExportSpansChainsDottedOrderAcrossParentAndChild(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("exporter-test")
+
+	ctx := context.Background()
+	parentCtx, parentSpan := tracer.Start(ctx, "parent")
+	_, childSpan := tracer.Start(parentCtx, "child")
+	childSpan.End()
+	parentSpan.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("recorder.Ended() returned %d spans, want 2", len(ended))
+	}
+	if ended[0].Name() != "child" {
+		t.Fatalf("ended[0].Name() = %q, want %q (child should end, and be exported, first)", ended[0].Name(), "child")
+	}
+
+	posts := map[string]*langsmithgo.PostPayload{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("parse content type: %v", err)
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			var post langsmithgo.PostPayload
+			if err := json.NewDecoder(part).Decode(&post); err != nil {
+				continue
+			}
+			posts[post.ID] = &post
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	t.Setenv("LANGSMITH_API_KEY", "key")
+	client, err := langsmithgo.NewClient("proj", langsmithgo.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ingestor := langsmithgo.NewBatchIngestor(client, langsmithgo.BatchIngestorOptions{MaxWait: time.Hour})
+
+	exporter := NewExporter(ingestor)
+	if err := exporter.ExportSpans(ctx, ended); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+	if err := ingestor.Close(context.Background()); err != nil {
+		t.Fatalf("ingestor.Close: %v", err)
+	}
+
+	childID := childSpan.SpanContext().SpanID().String()
+	parentID := parentSpan.SpanContext().SpanID().String()
+
+	childPost, ok := posts[childID]
+	if !ok {
+		t.Fatalf("no post enqueued for child span %s; posts: %v", childID, posts)
+	}
+	parentPost, ok := posts[parentID]
+	if !ok {
+		t.Fatalf("no post enqueued for parent span %s; posts: %v", parentID, posts)
+	}
+
+	childDotted, _ := childPost.Extras["dotted_order"].(string)
+	parentDotted, _ := parentPost.Extras["dotted_order"].(string)
+
+	if childDotted == "" || parentDotted == "" {
+		t.Fatalf("dotted_order missing: child=%q parent=%q", childDotted, parentDotted)
+	}
+	wantPrefix := parentDotted + "."
+	if len(childDotted) <= len(wantPrefix) || childDotted[:len(wantPrefix)] != wantPrefix {
+		t.Errorf("child dotted_order = %q, want it prefixed with parent's %q (chained, not a bare unchained segment)", childDotted, wantPrefix)
+	}
+	if !strings.HasSuffix(childDotted, childID) {
+		t.Errorf("child dotted_order = %q, want it to end with the child's own span ID %q", childDotted, childID)
+	}
+}
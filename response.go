@@ -0,0 +1,50 @@
+package langsmithgo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// handleResponse returns nil for a successful response, or the *APIError
+// describing a non-2xx one. It is the canonical error check every method in
+// this package runs on a response before reading its body.
+func handleResponse(resp *http.Response) error {
+	return parseAPIError(resp)
+}
+
+// getJSON issues a GET against path and decodes the JSON response into out,
+// replacing the build-request/set-header/Do/handleResponse/ReadAll/Unmarshal
+// sequence repeated across this package's GET methods.
+func (b *baseClient) getJSON(ctx context.Context, path string, out any) error {
+	body, err := b.getRaw(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return json.NewDecoder(body).Decode(out)
+}
+
+// getRaw issues a GET against path and returns the response body for
+// streaming, without buffering it into memory. The caller must close it.
+func (b *baseClient) getRaw(ctx context.Context, path string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", b.APIKey)
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := parseAPIError(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,146 @@
+package langsmithgo
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how baseClient.do retries a request that failed with
+// a transient network error or a retryable status code.
+type RetryPolicy struct {
+	MaxRetries        int
+	MinWait           time.Duration
+	MaxWait           time.Duration
+	RetryableStatuses []int
+	Jitter            bool
+}
+
+// DefaultRetryPolicy retries 429, 500, 502, 503 and 504 up to 3 times with decorrelated
+// jitter between 200ms and 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:        3,
+		MinWait:           200 * time.Millisecond,
+		MaxWait:           5 * time.Second,
+		RetryableStatuses: []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		Jitter:            true,
+	}
+}
+
+func (p RetryPolicy) isRetryable(status int) bool {
+	for _, s := range p.RetryableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// nextWait computes the next backoff duration using decorrelated jitter:
+// sleep = min(MaxWait, random_between(MinWait, prev*3)). When Jitter is
+// false it instead returns min(MaxWait, prev*2).
+func (p RetryPolicy) nextWait(prev time.Duration) time.Duration {
+	if prev <= 0 {
+		prev = p.MinWait
+	}
+	if !p.Jitter {
+		w := prev * 2
+		if w > p.MaxWait {
+			w = p.MaxWait
+		}
+		return w
+	}
+
+	upper := prev * 3
+	if upper <= p.MinWait {
+		return p.MinWait
+	}
+	w := p.MinWait + time.Duration(rand.Int63n(int64(upper-p.MinWait)))
+	if w > p.MaxWait {
+		w = p.MaxWait
+	}
+	return w
+}
+
+// retryAfterDuration honors a Retry-After header (either delta-seconds or an
+// HTTP date) on any retryable response do passes it, such as a 429 or 503,
+// falling back to the policy's own backoff when the header is absent or
+// unparsable.
+func retryAfterDuration(resp *http.Response, fallback time.Duration) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return fallback
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return fallback
+}
+
+// do sends req, retrying on transient network errors and the policy's
+// retryable status codes with exponential backoff and jitter. req's body
+// must support GetBody (as http.NewRequest/NewRequestWithContext arrange
+// for []byte/bytes.Buffer/bytes.Reader bodies) so it can be replayed across
+// attempts.
+func (b *baseClient) do(req *http.Request) (*http.Response, error) {
+	policy := DefaultRetryPolicy()
+	if b.retryPolicy != nil {
+		policy = *b.retryPolicy
+	}
+
+	var lastErr error
+	wait := policy.MinWait
+
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(wait):
+			}
+		}
+
+		if b.traceHooks != nil && b.traceHooks.OnRequest != nil {
+			b.traceHooks.OnRequest(req, dumpRequestForTrace(req))
+		}
+
+		start := time.Now()
+		resp, err := b.client().Do(req)
+		elapsed := time.Since(start)
+
+		if err != nil {
+			if b.traceHooks != nil && b.traceHooks.OnError != nil {
+				b.traceHooks.OnError(req, err)
+			}
+			lastErr = err
+			wait = policy.nextWait(wait)
+			continue
+		}
+
+		if b.traceHooks != nil && b.traceHooks.OnResponse != nil {
+			b.traceHooks.OnResponse(resp, dumpResponseForTrace(resp), elapsed)
+		}
+
+		if policy.isRetryable(resp.StatusCode) && attempt < policy.MaxRetries {
+			wait = retryAfterDuration(resp, policy.nextWait(wait))
+			resp.Body.Close()
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,98 @@
+package langsmithgo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusNotFound, false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.isRetryable(tt.status); got != tt.want {
+			t.Errorf("isRetryable(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyNextWaitBounds(t *testing.T) {
+	policy := RetryPolicy{
+		MinWait: 100 * time.Millisecond,
+		MaxWait: time.Second,
+		Jitter:  true,
+	}
+
+	prev := time.Duration(0)
+	for i := 0; i < 50; i++ {
+		w := policy.nextWait(prev)
+		if w < policy.MinWait {
+			t.Fatalf("nextWait(%v) = %v, want >= MinWait %v", prev, w, policy.MinWait)
+		}
+		if w > policy.MaxWait {
+			t.Fatalf("nextWait(%v) = %v, want <= MaxWait %v", prev, w, policy.MaxWait)
+		}
+		prev = w
+	}
+}
+
+func TestRetryPolicyNextWaitWithoutJitterDoubles(t *testing.T) {
+	policy := RetryPolicy{
+		MinWait: 100 * time.Millisecond,
+		MaxWait: time.Second,
+		Jitter:  false,
+	}
+
+	if got := policy.nextWait(200 * time.Millisecond); got != 400*time.Millisecond {
+		t.Errorf("nextWait(200ms) = %v, want 400ms", got)
+	}
+	if got := policy.nextWait(800 * time.Millisecond); got != policy.MaxWait {
+		t.Errorf("nextWait(800ms) = %v, want capped at MaxWait %v", got, policy.MaxWait)
+	}
+}
+
+func TestBaseClientDoHonorsExplicitZeroRetryPolicy(t *testing.T) {
+	b := &baseClient{retryPolicy: &RetryPolicy{MaxRetries: 0}}
+
+	attempts := 0
+	req, err := http.NewRequest(http.MethodGet, "http://example.invalid", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	b.httpClient = &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errAlwaysFails
+	})}
+
+	if _, err := b.do(req); err == nil {
+		t.Fatal("expected an error from a failing transport")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (MaxRetries: 0 must mean no retries, not the 3-retry default)", attempts)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var errAlwaysFails = &testTransportError{"transport always fails"}
+
+type testTransportError struct{ msg string }
+
+func (e *testTransportError) Error() string { return e.msg }
@@ -0,0 +1,52 @@
+package langsmithgo
+
+import "context"
+
+// RunsIterator paginates a DatasetClient.QueryRunsIterator call, re-issuing
+// the underlying /runs/query request with the server's cursor until the
+// results are exhausted or ctx is cancelled.
+type RunsIterator struct {
+	client        *DatasetClient
+	ctx           context.Context
+	experimentIds []string
+	isRoot        bool
+	params        *QueryParams
+
+	p runPaginator
+}
+
+// QueryRunsIterator is QueryRuns, but returns a RunsIterator that
+// transparently re-issues the request with the server's cursor as the
+// caller advances it, instead of requiring the caller to page by hand.
+func (d *DatasetClient) QueryRunsIterator(ctx context.Context, experimentIds []string, isRoot bool, queryParams *QueryParams) *RunsIterator {
+	it := &RunsIterator{
+		client:        d,
+		ctx:           ctx,
+		experimentIds: experimentIds,
+		isRoot:        isRoot,
+		params:        queryParams,
+	}
+	it.p.idx = -1
+	return it
+}
+
+// Next advances the iterator, fetching the next page from the server when
+// the current one is exhausted.
+func (it *RunsIterator) Next() bool {
+	return it.p.advance(it.ctx, it.fetchPage)
+}
+
+func (it *RunsIterator) fetchPage(cursor string) (*RunsResponse, error) {
+	return it.client.queryRunsPage(it.ctx, it.experimentIds, it.isRoot, it.params, cursor)
+}
+
+// Value returns the run at the iterator's current position. Only valid
+// after a call to Next that returned true.
+func (it *RunsIterator) Value() Run {
+	return it.p.runs[it.p.idx]
+}
+
+// Err returns the first error encountered while paginating, if any.
+func (it *RunsIterator) Err() error {
+	return it.p.err
+}
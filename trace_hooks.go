@@ -0,0 +1,53 @@
+package langsmithgo
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+)
+
+// TraceHooks lets a caller inspect every request and response the client
+// makes, for diagnosing LangSmith API failures in production. Any hook left
+// nil is simply not called.
+type TraceHooks struct {
+	OnRequest  func(req *http.Request, body []byte)
+	OnResponse func(resp *http.Response, body []byte, elapsed time.Duration)
+	OnError    func(req *http.Request, err error)
+}
+
+const redactedHeaderValue = "[REDACTED]"
+
+// dumpRequestForTrace renders req as httputil.DumpRequestOut would, with the
+// x-api-key header redacted and the body suppressed for multipart uploads
+// (e.g. UploadCSV) so large file contents don't end up in trace output.
+func dumpRequestForTrace(req *http.Request) []byte {
+	includeBody := !strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/")
+
+	dump, err := httputil.DumpRequestOut(req, includeBody)
+	if err != nil {
+		return nil
+	}
+	return redactAPIKeyHeader(dump)
+}
+
+// dumpResponseForTrace renders resp as httputil.DumpResponse would, with the
+// x-api-key header redacted if the server happened to echo it back.
+func dumpResponseForTrace(resp *http.Response) []byte {
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil
+	}
+	return redactAPIKeyHeader(dump)
+}
+
+func redactAPIKeyHeader(dump []byte) []byte {
+	lines := bytes.Split(dump, []byte("\r\n"))
+	for i, line := range lines {
+		if len(line) > 10 && strings.EqualFold(string(line[:10]), "x-api-key:") {
+			lines[i] = append([]byte("x-api-key: "), []byte(redactedHeaderValue)...)
+		}
+	}
+	return bytes.Join(lines, []byte("\r\n"))
+}